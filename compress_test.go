@@ -0,0 +1,84 @@
+package siec
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestCompressDecompressRoundTrip(t *testing.T) {
+	curve := SIEC255()
+	_, x, y, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	compressed := CompressPoint(x, y)
+	if len(compressed) != 33 {
+		t.Fatalf("CompressPoint returned %d bytes, want 33", len(compressed))
+	}
+
+	gotX, gotY, err := DecompressPoint(x, y.Bit(0) == 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotX.Cmp(x) != 0 || gotY.Cmp(y) != 0 {
+		t.Fatalf("DecompressPoint(x, yOdd) = (%s, %s), want (%s, %s)", gotX, gotY, x, y)
+	}
+	if !curve.IsOnCurve(gotX, gotY) {
+		t.Fatal("decompressed point is not on the curve")
+	}
+}
+
+// findOffCurveX searches backwards from P for an x with no corresponding
+// curve point. x = P is never a valid x-coordinate (reduced field elements
+// are < P), and it's vanishingly unlikely x³+19 is itself a QR for a random
+// x, so a handful of candidates is enough to find one off the curve.
+func findOffCurveX(t *testing.T) *big.Int {
+	t.Helper()
+	curve := SIEC255()
+	candidate := new(big.Int).Set(curve.P)
+	for i := 0; i < 8; i++ {
+		candidate = new(big.Int).Sub(candidate, big.NewInt(1))
+		if _, err := curveSqrtY(candidate); err != nil {
+			return candidate
+		}
+	}
+	t.Skip("could not quickly find an x with no corresponding curve point")
+	return nil
+}
+
+func TestDecompressPointRejectsBadX(t *testing.T) {
+	badX := findOffCurveX(t)
+
+	if _, _, err := DecompressPoint(badX, false); err == nil {
+		t.Fatal("DecompressPoint did not error for an x with no point on the curve")
+	}
+}
+
+func TestLiftXPanicsOnBadX(t *testing.T) {
+	badX := findOffCurveX(t)
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("LiftX did not panic for an x with no point on the curve")
+		}
+	}()
+	LiftX(badX)
+}
+
+func TestLiftXMatchesDecompressPointUpToSign(t *testing.T) {
+	_, x, y, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lx, ly := LiftX(x)
+	if lx.Cmp(x) != 0 {
+		t.Fatalf("LiftX returned x = %s, want %s", lx, x)
+	}
+	curve := SIEC255()
+	otherRoot := new(big.Int).Sub(curve.P, y)
+	if ly.Cmp(y) != 0 && ly.Cmp(otherRoot) != 0 {
+		t.Fatalf("LiftX's y (%s) is neither of the two valid roots for x (%s or %s)", ly, y, otherRoot)
+	}
+}
@@ -0,0 +1,172 @@
+package siec
+
+import (
+	"crypto/subtle"
+	"math/big"
+)
+
+// ScalarMult, ScalarBaseMult (and the Add/Double they're built from) are NOT
+// constant-time: Add is only called when a scalar bit is set, so the timing
+// and the pattern of big.Int allocations both leak the scalar. Any caller
+// that scalar-multiplies by a secret — a private key, an ECDH input — must
+// use ScalarMultCT / ScalarBaseMultCT instead. GenerateKey already does.
+//
+// ScalarMultCT removes the data-dependent branching in the double-and-add
+// loop: every bit performs the same sequence of field operations, chosen
+// between with a constant-time select instead of an if. It does not (and,
+// short of replacing math/big with a fixed-width limb representation,
+// cannot) remove every timing signal: big.Int's own multiplication and
+// reduction are faster on operands with fewer words, so an accumulator that
+// is still the point at infinity for the first several iterations — e.g.
+// because the scalar has many leading zero bits — is measurably cheaper to
+// process than one that is already a full-size point. Eliminating that
+// residual leak would mean porting the field arithmetic to a fixed-size
+// uint64-limb representation instead of math/big.
+
+// ctSelect returns a if bit == 1, b if bit == 0, in constant time, by
+// serializing both to byteLen-byte buffers and using a constant-time copy
+// rather than branching on bit.
+//
+// a and b are reduced mod p first so that a non-canonical operand (larger
+// than byteLen bytes, e.g. because some caller upstream skipped a range
+// check on external input) gets folded back into range instead of
+// overflowing the buffer and panicking in FillBytes — every field value
+// addJacobianCT and ScalarMultCT pass through here is supposed to already
+// be in [0, p), so this Mod is a backstop, not the primary validation.
+func ctSelect(bit uint, a, b *big.Int, p *big.Int, byteLen int) *big.Int {
+	abuf := make([]byte, byteLen)
+	new(big.Int).Mod(a, p).FillBytes(abuf)
+	out := make([]byte, byteLen)
+	new(big.Int).Mod(b, p).FillBytes(out)
+	subtle.ConstantTimeCopy(int(bit), out, abuf)
+	return new(big.Int).SetBytes(out)
+}
+
+// boolToUint converts a bool derived from secret data (e.g. curve.Sign()
+// checks below) to 0/1 for ctSelect. Evaluating these predicates is cheap,
+// data-independent-size arithmetic; what must stay constant-time is the big
+// number arithmetic they gate, which addJacobianCT always performs in full
+// regardless of the result.
+func boolToUint(b bool) uint {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// addJacobianCT is the constant-time analogue of addJacobian. addJacobian's
+// early returns for z1==0, z2==0, and the P==Q (double) case each skip the
+// general-case arithmetic entirely, so their cost — and therefore the
+// wall-clock time of a caller like ScalarMultCT — depends on secret data
+// (e.g. whether the running accumulator is still the point at infinity,
+// which leaks the number of leading zero bits in the scalar). addJacobianCT
+// instead always computes the general addition formula and the doubling
+// formula, and picks the right one with a constant-time select, so every
+// call costs the same regardless of which case applies.
+func (curve *SIEC255Params) addJacobianCT(x1, y1, z1, x2, y2, z2 *big.Int) (x3, y3, z3 *big.Int) {
+	byteLen := (curve.P.BitLen() + 7) / 8
+
+	z1z1 := new(big.Int).Mul(z1, z1)
+	z1z1.Mod(z1z1, curve.P)
+	z2z2 := new(big.Int).Mul(z2, z2)
+	z2z2.Mod(z2z2, curve.P)
+
+	u1 := new(big.Int).Mul(x1, z2z2)
+	u1.Mod(u1, curve.P)
+	u2 := new(big.Int).Mul(x2, z1z1)
+	u2.Mod(u2, curve.P)
+	h := new(big.Int).Sub(u2, u1)
+	h.Mod(h, curve.P)
+	xEqual := h.Sign() == 0
+
+	i := new(big.Int).Lsh(h, 1)
+	i.Mul(i, i)
+	j := new(big.Int).Mul(h, i)
+
+	s1 := new(big.Int).Mul(y1, z2)
+	s1.Mul(s1, z2z2)
+	s1.Mod(s1, curve.P)
+	s2 := new(big.Int).Mul(y2, z1)
+	s2.Mul(s2, z1z1)
+	s2.Mod(s2, curve.P)
+	r := new(big.Int).Sub(s2, s1)
+	r.Mod(r, curve.P)
+	yEqual := r.Sign() == 0
+
+	v := new(big.Int).Mul(u1, i)
+	rGen := new(big.Int).Lsh(r, 1)
+
+	gx := new(big.Int).Mul(rGen, rGen)
+	gx.Sub(gx, j)
+	gx.Sub(gx, v)
+	gx.Sub(gx, v)
+	gx.Mod(gx, curve.P)
+
+	gy := new(big.Int).Sub(v, gx)
+	gy.Mul(rGen, gy)
+	s1j := new(big.Int).Mul(s1, j)
+	s1j.Lsh(s1j, 1)
+	gy.Sub(gy, s1j)
+	gy.Mod(gy, curve.P)
+
+	gz := new(big.Int).Add(z1, z2)
+	gz.Mul(gz, gz)
+	gz.Sub(gz, z1z1)
+	gz.Sub(gz, z2z2)
+	gz.Mul(gz, h)
+	gz.Mod(gz, curve.P)
+
+	// Computed unconditionally alongside the general formula above, so
+	// selecting into it below costs nothing extra in terms of branching on
+	// secret data.
+	ddx, ddy, ddz := curve.doubleJacobian(x1, y1, z1)
+
+	z1Zero := z1.Sign() == 0
+	z2Zero := z2.Sign() == 0
+	isDouble := xEqual && yEqual && !z1Zero && !z2Zero
+
+	x3 = ctSelect(boolToUint(isDouble), ddx, gx, curve.P, byteLen)
+	y3 = ctSelect(boolToUint(isDouble), ddy, gy, curve.P, byteLen)
+	z3 = ctSelect(boolToUint(isDouble), ddz, gz, curve.P, byteLen)
+
+	x3 = ctSelect(boolToUint(z2Zero), x1, x3, curve.P, byteLen)
+	y3 = ctSelect(boolToUint(z2Zero), y1, y3, curve.P, byteLen)
+	z3 = ctSelect(boolToUint(z2Zero), z1, z3, curve.P, byteLen)
+
+	x3 = ctSelect(boolToUint(z1Zero), x2, x3, curve.P, byteLen)
+	y3 = ctSelect(boolToUint(z1Zero), y2, y3, curve.P, byteLen)
+	z3 = ctSelect(boolToUint(z1Zero), z2, z3, curve.P, byteLen)
+
+	return x3, y3, z3
+}
+
+// ScalarMultCT is a constant-time variant of ScalarMult: every bit of k
+// performs both a Double and an Add — via doubleJacobian, which is already
+// branch-free, and addJacobianCT rather than addJacobian — and the result is
+// chosen with a constant-time conditional copy instead of an if, removing
+// the branches that made ScalarMult's timing depend on k. See the note
+// above for the residual, math/big-rooted timing signal this doesn't close.
+func (curve *SIEC255Params) ScalarMultCT(x1, y1 *big.Int, k []byte) (x, y *big.Int) {
+	byteLen := (curve.P.BitLen() + 7) / 8
+	Bz := zForAffine(x1, y1)
+	x, y, z := new(big.Int), new(big.Int), new(big.Int)
+
+	for _, b := range k {
+		for bitNum := 0; bitNum < 8; bitNum++ {
+			dx, dy, dz := curve.doubleJacobian(x, y, z)
+			ax, ay, az := curve.addJacobianCT(x1, y1, Bz, dx, dy, dz)
+			bit := uint(b>>7) & 1
+			x = ctSelect(bit, ax, dx, curve.P, byteLen)
+			y = ctSelect(bit, ay, dy, curve.P, byteLen)
+			z = ctSelect(bit, az, dz, curve.P, byteLen)
+			b <<= 1
+		}
+	}
+	return curve.affineFromJacobian(x, y, z)
+}
+
+// ScalarBaseMultCT is the constant-time analogue of ScalarBaseMult: it
+// returns k*G for the secret scalar k.
+func (curve *SIEC255Params) ScalarBaseMultCT(k []byte) (x, y *big.Int) {
+	return curve.ScalarMultCT(curve.Gx, curve.Gy, k)
+}
@@ -0,0 +1,118 @@
+package siec
+
+import (
+	"crypto/elliptic"
+	"math/big"
+)
+
+// Curve adapts SIEC255Params to the standard library's crypto/elliptic.Curve
+// interface, so SIEC255 can be plugged into ecdsa.GenerateKey, crypto/tls
+// custom-curve registration, or anything else that only knows about
+// elliptic.Curve.
+//
+// SIEC255Params.Params returns *SIEC255Params, which is convenient for this
+// package's own arithmetic but isn't the *elliptic.CurveParams that
+// elliptic.Curve requires. Curve embeds SIEC255Params for its arithmetic
+// methods and shadows Params with one that returns *elliptic.CurveParams.
+type Curve struct {
+	*SIEC255Params
+	params *elliptic.CurveParams
+}
+
+// Params returns the curve parameters in the form expected by
+// crypto/elliptic.Curve. Note that elliptic.CurveParams has no field for the
+// x¹ coefficient A; SIEC255's A is 0, and Curve's Add/Double/ScalarMult
+// methods (promoted from SIEC255Params) never fall back to the generic
+// a=-3 arithmetic crypto/elliptic would otherwise assume.
+func (curve *Curve) Params() *elliptic.CurveParams {
+	return curve.params
+}
+
+var siec255Curve *Curve
+
+// SIEC255Curve returns a Curve implementing crypto/elliptic.Curve for SIEC255.
+func SIEC255Curve() *Curve {
+	params := SIEC255()
+	return &Curve{
+		SIEC255Params: params,
+		params: &elliptic.CurveParams{
+			P:       params.P,
+			N:       params.N,
+			B:       params.B,
+			Gx:      params.Gx,
+			Gy:      params.Gy,
+			BitSize: params.BitSize,
+			Name:    params.Name,
+		},
+	}
+}
+
+// Marshal converts a point on the curve into the uncompressed form
+// specified in SEC1, section 2.3.3: 0x04 || X || Y.
+func Marshal(curve elliptic.Curve, x, y *big.Int) []byte {
+	byteLen := (curve.Params().BitSize + 7) / 8
+
+	ret := make([]byte, 1+2*byteLen)
+	ret[0] = 4 // uncompressed point
+
+	x.FillBytes(ret[1 : 1+byteLen])
+	y.FillBytes(ret[1+byteLen : 1+2*byteLen])
+
+	return ret
+}
+
+// MarshalCompressed converts a point on the curve into the compressed form
+// specified in SEC1, section 2.3.3: 0x02/0x03 || X. See CompressPoint.
+func MarshalCompressed(curve elliptic.Curve, x, y *big.Int) []byte {
+	return CompressPoint(x, y)
+}
+
+// Unmarshal converts a point, serialized by Marshal, into an x, y pair. It
+// returns nil, nil if the point is not in uncompressed form, is not on the
+// curve, or is the point at infinity.
+func Unmarshal(curve elliptic.Curve, data []byte) (x, y *big.Int) {
+	byteLen := (curve.Params().BitSize + 7) / 8
+	if len(data) != 1+2*byteLen {
+		return nil, nil
+	}
+	if data[0] != 4 { // uncompressed form
+		return nil, nil
+	}
+	p := curve.Params().P
+	x = new(big.Int).SetBytes(data[1 : 1+byteLen])
+	y = new(big.Int).SetBytes(data[1+byteLen:])
+	if x.Cmp(p) >= 0 || y.Cmp(p) >= 0 {
+		return nil, nil
+	}
+	if !curve.IsOnCurve(x, y) {
+		return nil, nil
+	}
+	return x, y
+}
+
+// UnmarshalCompressed converts a point, serialized by MarshalCompressed, into
+// an x, y pair. It returns nil, nil if the point is not in compressed form,
+// x does not correspond to a point on the curve, or the recovered point
+// otherwise isn't on the curve.
+func UnmarshalCompressed(curve elliptic.Curve, data []byte) (x, y *big.Int) {
+	byteLen := (curve.Params().BitSize + 7) / 8
+	if len(data) != 1+byteLen {
+		return nil, nil
+	}
+	if data[0] != 2 && data[0] != 3 { // compressed form
+		return nil, nil
+	}
+	p := curve.Params().P
+	x = new(big.Int).SetBytes(data[1:])
+	if x.Cmp(p) >= 0 {
+		return nil, nil
+	}
+	x, y, err := DecompressPoint(x, data[0] == 3)
+	if err != nil {
+		return nil, nil
+	}
+	if !curve.IsOnCurve(x, y) {
+		return nil, nil
+	}
+	return x, y
+}
@@ -0,0 +1,65 @@
+package siec
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+func TestMarshalUnmarshalRoundTrip(t *testing.T) {
+	curve := SIEC255Curve()
+	_, x, y, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := Marshal(curve, x, y)
+	if len(data) != 1+2*32 || data[0] != 4 {
+		t.Fatalf("Marshal produced %d bytes with prefix 0x%02x, want 65 bytes with prefix 0x04", len(data), data[0])
+	}
+
+	gotX, gotY := Unmarshal(curve, data)
+	if gotX == nil {
+		t.Fatal("Unmarshal rejected data Marshal just produced")
+	}
+	if gotX.Cmp(x) != 0 || gotY.Cmp(y) != 0 {
+		t.Fatalf("Unmarshal(Marshal(x, y)) = (%s, %s), want (%s, %s)", gotX, gotY, x, y)
+	}
+}
+
+func TestMarshalCompressedRoundTrip(t *testing.T) {
+	curve := SIEC255Curve()
+	_, x, y, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := MarshalCompressed(curve, x, y)
+	if len(data) != 1+32 || (data[0] != 2 && data[0] != 3) {
+		t.Fatalf("MarshalCompressed produced %d bytes with prefix 0x%02x, want 33 bytes with prefix 0x02/0x03", len(data), data[0])
+	}
+
+	gotX, gotY := UnmarshalCompressed(curve, data)
+	if gotX == nil {
+		t.Fatal("UnmarshalCompressed rejected data MarshalCompressed just produced")
+	}
+	if gotX.Cmp(x) != 0 || gotY.Cmp(y) != 0 {
+		t.Fatalf("UnmarshalCompressed(MarshalCompressed(x, y)) = (%s, %s), want (%s, %s)", gotX, gotY, x, y)
+	}
+}
+
+func TestUnmarshalRejectsGarbage(t *testing.T) {
+	curve := SIEC255Curve()
+
+	if x, _ := Unmarshal(curve, []byte{1, 2, 3}); x != nil {
+		t.Fatal("Unmarshal accepted a too-short buffer")
+	}
+	if x, _ := Unmarshal(curve, make([]byte, 65)); x != nil {
+		t.Fatal("Unmarshal accepted 65 zero bytes (bad prefix, off-curve)")
+	}
+	if x, _ := UnmarshalCompressed(curve, []byte{2}); x != nil {
+		t.Fatal("UnmarshalCompressed accepted a too-short buffer")
+	}
+	if x, _ := UnmarshalCompressed(curve, make([]byte, 33)); x != nil {
+		t.Fatal("UnmarshalCompressed accepted an all-zero buffer (bad prefix)")
+	}
+}
@@ -0,0 +1,134 @@
+package siec
+
+import (
+	"errors"
+	"io"
+	"math/big"
+)
+
+// ECDH computes the shared secret between priv and the public key (pubX,
+// pubY): the x-coordinate of priv·Pub, encoded as a big-endian byte string
+// padded to (BitSize+7)/8 bytes, which is the usual form for a KEM shared
+// secret (e.g. as used by TLS).
+//
+// Because pubX, pubY may come from an untrusted peer, ECDH validates that
+// the point lies on the curve and in the prime-order subgroup generated by
+// G (N·Pub = O) before using it — skipping either check opens the door to
+// invalid-curve or small-subgroup attacks. It also rejects a result that is
+// the point at infinity, which priv·Pub can only be if Pub was invalid.
+//
+// priv is typically a long-lived static key reused across many exchanges
+// (unlike an ECDSA nonce, which is fresh per signature), so its
+// multiplication goes through ScalarMultCT rather than ScalarMult — see
+// ScalarMultCT's doc for exactly what timing guarantee that does and
+// doesn't provide. The subgroup check above is public-input validation, so
+// it uses the non-constant-time ScalarMult.
+func ECDH(priv []byte, pubX, pubY *big.Int) ([]byte, error) {
+	curve := SIEC255()
+
+	if pubX.Sign() < 0 || pubX.Cmp(curve.P) >= 0 || pubY.Sign() < 0 || pubY.Cmp(curve.P) >= 0 {
+		return nil, errors.New("siec: public key coordinate is not canonical")
+	}
+	if !curve.IsOnCurve(pubX, pubY) {
+		return nil, errors.New("siec: public key is not on the curve")
+	}
+	nx, ny := curve.ScalarMult(pubX, pubY, curve.N.Bytes())
+	if nx.Sign() != 0 || ny.Sign() != 0 {
+		return nil, errors.New("siec: public key is not in the prime-order subgroup")
+	}
+
+	x, y := curve.ScalarMultCT(pubX, pubY, priv)
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return nil, errors.New("siec: ECDH result is the point at infinity")
+	}
+
+	byteLen := (curve.BitSize + 7) / 8
+	shared := make([]byte, byteLen)
+	x.FillBytes(shared)
+	return shared, nil
+}
+
+// PrivateKey and PublicKey mirror the shape of crypto/ecdh's types for
+// SIEC255.
+//
+// crypto/ecdh.Curve carries an unexported ecdh() method specifically so that
+// only the standard library's own curves can implement it (see the doc
+// comment on ecdh.Curve) — ECDHCurve therefore cannot literally satisfy
+// ecdh.Curve. It mirrors ecdh.Curve's exported method set instead
+// (GenerateKey, NewPrivateKey, NewPublicKey, and PrivateKey.ECDH) so code
+// already written against crypto/ecdh's shape ports to SIEC255 with minimal
+// changes.
+type PrivateKey struct {
+	priv       []byte
+	pubX, pubY *big.Int
+}
+
+// Bytes returns a copy of the private scalar.
+func (k *PrivateKey) Bytes() []byte {
+	return append([]byte(nil), k.priv...)
+}
+
+// PublicKey returns the public key corresponding to k.
+func (k *PrivateKey) PublicKey() *PublicKey {
+	return &PublicKey{x: k.pubX, y: k.pubY}
+}
+
+// ECDH computes the shared secret between k and pub; see the package-level
+// ECDH function.
+func (k *PrivateKey) ECDH(pub *PublicKey) ([]byte, error) {
+	return ECDH(k.priv, pub.x, pub.y)
+}
+
+// PublicKey is a SIEC255 public key.
+type PublicKey struct {
+	x, y *big.Int
+}
+
+// Bytes returns the uncompressed (0x04 || X || Y) encoding of the key.
+func (k *PublicKey) Bytes() []byte {
+	return Marshal(SIEC255Curve(), k.x, k.y)
+}
+
+// ECDHCurve adapts SIEC255 to the method shape of crypto/ecdh.Curve.
+type ECDHCurve struct{}
+
+// SIEC255ECDH returns an ECDHCurve for SIEC255.
+func SIEC255ECDH() ECDHCurve {
+	return ECDHCurve{}
+}
+
+// GenerateKey generates a random private key, reading randomness from rand.
+func (ECDHCurve) GenerateKey(rand io.Reader) (*PrivateKey, error) {
+	k, x, y, err := GenerateKey(rand)
+	if err != nil {
+		return nil, err
+	}
+	return &PrivateKey{priv: k, pubX: x, pubY: y}, nil
+}
+
+// NewPrivateKey checks that key is a valid private scalar for SIEC255 (in
+// [1, N-1]) and returns the corresponding PrivateKey.
+func (ECDHCurve) NewPrivateKey(key []byte) (*PrivateKey, error) {
+	curve := SIEC255()
+	d := new(big.Int).SetBytes(key)
+	if len(key) == 0 || d.Sign() == 0 || d.Cmp(curve.N) >= 0 {
+		return nil, errors.New("siec: invalid private key")
+	}
+	x, y := curve.ScalarBaseMultCT(key)
+	return &PrivateKey{priv: append([]byte(nil), key...), pubX: x, pubY: y}, nil
+}
+
+// NewPublicKey parses a public key in either the uncompressed (0x04 || X ||
+// Y) or compressed (0x02/0x03 || X) form and returns the corresponding
+// PublicKey.
+func (ECDHCurve) NewPublicKey(key []byte) (*PublicKey, error) {
+	curve := SIEC255Curve()
+	x, y := Unmarshal(curve, key)
+	if x == nil {
+		x, y = UnmarshalCompressed(curve, key)
+	}
+	if x == nil {
+		return nil, errors.New("siec: invalid public key")
+	}
+	return &PublicKey{x: x, y: y}, nil
+}
@@ -0,0 +1,144 @@
+package siec
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestECDHAgreement(t *testing.T) {
+	priv1, x1, y1, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	priv2, x2, y2, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s1, err := ECDH(priv1, x2, y2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s2, err := ECDH(priv2, x1, y1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(s1, s2) {
+		t.Fatalf("ECDH(priv1, pub2) = %x, ECDH(priv2, pub1) = %x", s1, s2)
+	}
+
+	byteLen := (SIEC255().BitSize + 7) / 8
+	if len(s1) != byteLen {
+		t.Fatalf("shared secret is %d bytes, want %d", len(s1), byteLen)
+	}
+}
+
+func TestECDHRejectsOffCurvePoint(t *testing.T) {
+	priv, _, _, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = ECDH(priv, big.NewInt(1), big.NewInt(2))
+	if err == nil {
+		t.Fatal("ECDH accepted a public key that is not on the curve")
+	}
+}
+
+func TestECDHRejectsNonCanonicalCoordinate(t *testing.T) {
+	priv, _, _, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, validX, validY, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	curve := SIEC255()
+	oversizedX := new(big.Int).Add(validX, new(big.Int).Mul(curve.P, big.NewInt(4)))
+	if _, err := ECDH(priv, oversizedX, validY); err == nil {
+		t.Fatal("ECDH accepted an x-coordinate that is not reduced mod P")
+	}
+
+	oversizedY := new(big.Int).Add(validY, new(big.Int).Mul(curve.P, big.NewInt(4)))
+	if _, err := ECDH(priv, validX, oversizedY); err == nil {
+		t.Fatal("ECDH accepted a y-coordinate that is not reduced mod P")
+	}
+
+	if _, err := ECDH(priv, new(big.Int).Neg(validX), validY); err == nil {
+		t.Fatal("ECDH accepted a negative x-coordinate")
+	}
+}
+
+func TestECDHCurveWrapper(t *testing.T) {
+	curve := SIEC255ECDH()
+
+	k1, err := curve.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k2, err := curve.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s1, err := k1.ECDH(k2.PublicKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s2, err := k2.ECDH(k1.PublicKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(s1, s2) {
+		t.Fatal("wrapper ECDH did not agree")
+	}
+
+	parsedPriv, err := curve.NewPrivateKey(k1.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(parsedPriv.Bytes(), k1.Bytes()) {
+		t.Fatal("NewPrivateKey round trip changed the private scalar")
+	}
+
+	parsedPub, err := curve.NewPublicKey(k1.PublicKey().Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s3, err := k2.ECDH(parsedPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(s1, s3) {
+		t.Fatal("ECDH with a parsed (uncompressed) public key disagreed")
+	}
+
+	compressed := CompressPoint(k1.PublicKey().x, k1.PublicKey().y)
+	parsedPubCompressed, err := curve.NewPublicKey(compressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s4, err := k2.ECDH(parsedPubCompressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(s1, s4) {
+		t.Fatal("ECDH with a parsed (compressed) public key disagreed")
+	}
+}
+
+func TestECDHCurveNewPrivateKeyRejectsOutOfRange(t *testing.T) {
+	curve := SIEC255ECDH()
+	if _, err := curve.NewPrivateKey(nil); err == nil {
+		t.Fatal("NewPrivateKey accepted an empty key")
+	}
+	if _, err := curve.NewPrivateKey(make([]byte, 32)); err == nil {
+		t.Fatal("NewPrivateKey accepted the zero scalar")
+	}
+	if _, err := curve.NewPrivateKey(SIEC255().N.Bytes()); err == nil {
+		t.Fatal("NewPrivateKey accepted N, which is out of range")
+	}
+}
@@ -0,0 +1,252 @@
+package siec
+
+import (
+	"crypto/hmac"
+	"encoding/asn1"
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+)
+
+// hashToInt converts hashBytes, the output of a hash function, to an integer
+// reduced to the leftmost N.BitLen() bits, following SEC1 4.1.3 step 5 /
+// FIPS 186-4. This mirrors crypto/ecdsa's hashToInt.
+func hashToInt(hashBytes []byte, N *big.Int) *big.Int {
+	orderBits := N.BitLen()
+	orderBytes := (orderBits + 7) / 8
+	if len(hashBytes) > orderBytes {
+		hashBytes = hashBytes[:orderBytes]
+	}
+	ret := new(big.Int).SetBytes(hashBytes)
+	if excess := len(hashBytes)*8 - orderBits; excess > 0 {
+		ret.Rsh(ret, uint(excess))
+	}
+	return ret
+}
+
+// Sign signs hash (the output of hashing a larger message) with the private
+// scalar priv, as produced by GenerateKey, reading randomness from rand. It
+// follows SEC1/FIPS 186-4: k is sampled uniformly in [1, N-1] via
+// randFieldElement, (x1,_) = k·G, r = x1 mod N, s = k⁻¹(e + r·d) mod N where
+// e is hashToInt(hash), retrying if r or s come out zero.
+func Sign(rand io.Reader, priv, hash []byte) (r, s *big.Int, err error) {
+	curve := SIEC255()
+	N := curve.Params().N
+	d := new(big.Int).SetBytes(priv)
+	e := hashToInt(hash, N)
+
+	for {
+		var kBytes []byte
+		kBytes, err = randFieldElement(rand, N)
+		if err != nil {
+			return nil, nil, err
+		}
+		k := new(big.Int).SetBytes(kBytes)
+		if k.Sign() == 0 {
+			continue
+		}
+
+		x1, _ := curve.ScalarBaseMultCT(kBytes)
+		r = new(big.Int).Mod(x1, N)
+		if r.Sign() == 0 {
+			continue
+		}
+
+		kInv := new(big.Int).ModInverse(k, N)
+		s = new(big.Int).Mul(r, d)
+		s.Add(s, e)
+		s.Mul(s, kInv)
+		s.Mod(s, N)
+		if s.Sign() == 0 {
+			continue
+		}
+		return r, s, nil
+	}
+}
+
+// Verify reports whether (r, s) is a valid signature of hash for the public
+// key (pubX, pubY).
+func Verify(pubX, pubY *big.Int, hash []byte, r, s *big.Int) bool {
+	curve := SIEC255()
+	N := curve.Params().N
+	if r.Sign() <= 0 || s.Sign() <= 0 || r.Cmp(N) >= 0 || s.Cmp(N) >= 0 {
+		return false
+	}
+	e := hashToInt(hash, N)
+
+	w := new(big.Int).ModInverse(s, N)
+	u1 := new(big.Int).Mul(e, w)
+	u1.Mod(u1, N)
+	u2 := new(big.Int).Mul(r, w)
+	u2.Mod(u2, N)
+
+	x1, y1 := curve.ScalarBaseMult(u1.Bytes())
+	x2, y2 := curve.ScalarMult(pubX, pubY, u2.Bytes())
+	if x1.Sign() == 0 && y1.Sign() == 0 {
+		x1, y1 = x2, y2
+	} else if !(x2.Sign() == 0 && y2.Sign() == 0) {
+		x1, y1 = curve.Add(x1, y1, x2, y2)
+	}
+	if x1.Sign() == 0 && y1.Sign() == 0 {
+		return false
+	}
+
+	x1.Mod(x1, N)
+	return x1.Cmp(r) == 0
+}
+
+// ecdsaSignature is the ASN.1 structure SignASN1/VerifyASN1 encode (r, s)
+// as: a SEQUENCE of two INTEGERs, per SEC1.
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+// SignASN1 signs hash with priv (see Sign) and returns the signature as a
+// DER-encoded SEQUENCE of two INTEGERs, so it interoperates with standard
+// ECDSA tooling.
+func SignASN1(rand io.Reader, priv, hash []byte) ([]byte, error) {
+	r, s, err := Sign(rand, priv, hash)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(ecdsaSignature{R: r, S: s})
+}
+
+// VerifyASN1 verifies a DER-encoded (r, s) signature, as produced by
+// SignASN1, against hash and the public key (pubX, pubY).
+func VerifyASN1(pubX, pubY *big.Int, hash, sig []byte) bool {
+	var parsed ecdsaSignature
+	rest, err := asn1.Unmarshal(sig, &parsed)
+	if err != nil || len(rest) != 0 {
+		return false
+	}
+	if parsed.R == nil || parsed.S == nil || parsed.R.Sign() <= 0 || parsed.S.Sign() <= 0 {
+		return false
+	}
+	return Verify(pubX, pubY, hash, parsed.R, parsed.S)
+}
+
+// SignRFC6979 signs hash with priv like Sign, but derives k deterministically
+// per RFC 6979 §3.2 instead of reading randomness, using newHash (e.g.
+// sha256.New) to drive the HMAC-DRBG. The same (priv, hash) pair always
+// yields the same signature, which avoids leaking priv through a broken or
+// predictable RNG.
+func SignRFC6979(priv, hash []byte, newHash func() hash.Hash) (r, s *big.Int, err error) {
+	curve := SIEC255()
+	N := curve.Params().N
+	d := new(big.Int).SetBytes(priv)
+	e := hashToInt(hash, N)
+	byteLen := (N.BitLen() + 7) / 8
+
+	ok := rfc6979GenerateSecret(hash, priv, N, newHash, func(k *big.Int) bool {
+		kBytes := make([]byte, byteLen)
+		k.FillBytes(kBytes)
+
+		x1, _ := curve.ScalarBaseMultCT(kBytes)
+		r = new(big.Int).Mod(x1, N)
+		if r.Sign() == 0 {
+			return false
+		}
+
+		kInv := new(big.Int).ModInverse(k, N)
+		s = new(big.Int).Mul(r, d)
+		s.Add(s, e)
+		s.Mul(s, kInv)
+		s.Mod(s, N)
+		return s.Sign() != 0
+	})
+	if !ok {
+		return nil, nil, errors.New("siec: RFC 6979 nonce generation did not converge")
+	}
+	return r, s, nil
+}
+
+// rfc6979GenerateSecret implements the HMAC-DRBG of RFC 6979 §3.2, calling
+// candidate with each generated nonce k in turn until candidate reports it
+// usable. It reports whether a usable k was ever found; in practice the
+// first or second candidate always succeeds.
+func rfc6979GenerateSecret(hashBytes, priv []byte, N *big.Int, newHash func() hash.Hash, candidate func(k *big.Int) bool) bool {
+	qlen := N.BitLen()
+	holen := newHash().Size()
+	rolen := (qlen + 7) >> 3
+
+	bx := append(int2octets(new(big.Int).SetBytes(priv), rolen), bits2octets(hashBytes, N, qlen, rolen)...)
+
+	v := repeat(0x01, holen)
+	k := repeat(0x00, holen)
+
+	k = hmacSum(newHash, k, v, []byte{0x00}, bx)
+	v = hmacSum(newHash, k, v)
+	k = hmacSum(newHash, k, v, []byte{0x01}, bx)
+	v = hmacSum(newHash, k, v)
+
+	for attempts := 0; attempts < 1000; attempts++ {
+		var t []byte
+		for len(t)*8 < qlen {
+			v = hmacSum(newHash, k, v)
+			t = append(t, v...)
+		}
+
+		secret := bits2int(t, qlen)
+		if secret.Sign() > 0 && secret.Cmp(N) < 0 && candidate(secret) {
+			return true
+		}
+
+		k = hmacSum(newHash, k, v, []byte{0x00})
+		v = hmacSum(newHash, k, v)
+	}
+	return false
+}
+
+func repeat(b byte, n int) []byte {
+	out := make([]byte, n)
+	for i := range out {
+		out[i] = b
+	}
+	return out
+}
+
+func hmacSum(newHash func() hash.Hash, key []byte, data ...[]byte) []byte {
+	mac := hmac.New(newHash, key)
+	for _, d := range data {
+		mac.Write(d)
+	}
+	return mac.Sum(nil)
+}
+
+// bits2int is RFC 6979 §2.3.2: interpret in as an integer and right-shift
+// off any bits beyond qlen.
+func bits2int(in []byte, qlen int) *big.Int {
+	v := new(big.Int).SetBytes(in)
+	if vlen := len(in) * 8; vlen > qlen {
+		v.Rsh(v, uint(vlen-qlen))
+	}
+	return v
+}
+
+// int2octets is RFC 6979 §2.3.3: encode v as a big-endian byte string of
+// exactly rolen bytes.
+func int2octets(v *big.Int, rolen int) []byte {
+	out := v.Bytes()
+	if len(out) == rolen {
+		return out
+	}
+	if len(out) > rolen {
+		return out[len(out)-rolen:]
+	}
+	padded := make([]byte, rolen)
+	copy(padded[rolen-len(out):], out)
+	return padded
+}
+
+// bits2octets is RFC 6979 §2.3.4: bits2int followed by reduction mod N,
+// then int2octets.
+func bits2octets(in []byte, N *big.Int, qlen, rolen int) []byte {
+	z1 := bits2int(in, qlen)
+	z2 := new(big.Int).Sub(z1, N)
+	if z2.Sign() < 0 {
+		return int2octets(z1, rolen)
+	}
+	return int2octets(z2, rolen)
+}
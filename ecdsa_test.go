@@ -0,0 +1,143 @@
+package siec
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+)
+
+func TestSignVerify(t *testing.T) {
+	priv, x, y, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := sha256.Sum256([]byte("hello world"))
+
+	r, s, err := Sign(rand.Reader, priv, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Verify(x, y, hash[:], r, s) {
+		t.Fatal("Verify rejected a signature Sign just produced")
+	}
+
+	tampered := sha256.Sum256([]byte("goodbye world"))
+	if Verify(x, y, tampered[:], r, s) {
+		t.Fatal("Verify accepted a signature over the wrong hash")
+	}
+}
+
+func TestSignASN1VerifyASN1(t *testing.T) {
+	priv, x, y, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := sha256.Sum256([]byte("asn1 round trip"))
+
+	sig, err := SignASN1(rand.Reader, priv, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !VerifyASN1(x, y, hash[:], sig) {
+		t.Fatal("VerifyASN1 rejected a signature SignASN1 just produced")
+	}
+
+	sig[len(sig)-1] ^= 0xff
+	if VerifyASN1(x, y, hash[:], sig) {
+		t.Fatal("VerifyASN1 accepted a corrupted signature")
+	}
+}
+
+func TestSignRFC6979Deterministic(t *testing.T) {
+	priv, x, y, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := sha256.Sum256([]byte("deterministic signing"))
+
+	r1, s1, err := SignRFC6979(priv, hash[:], sha256.New)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r2, s2, err := SignRFC6979(priv, hash[:], sha256.New)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r1.Cmp(r2) != 0 || s1.Cmp(s2) != 0 {
+		t.Fatal("SignRFC6979 produced different signatures for the same input")
+	}
+	if !Verify(x, y, hash[:], r1, s1) {
+		t.Fatal("Verify rejected a SignRFC6979 signature")
+	}
+}
+
+// TestSignRFC6979KnownAnswer pins SignRFC6979's output for a fixed private
+// key and hash. SIEC255 is a custom curve with no published test vectors, so
+// the (r, s) below were derived independently of this package, from
+// testdata/gen_rfc6979_vector.py — a from-scratch Python reimplementation of
+// the curve arithmetic, RFC 6979 HMAC-DRBG, and ECDSA signing equations —
+// rather than by printing this package's own output.
+func TestSignRFC6979KnownAnswer(t *testing.T) {
+	priv := make([]byte, 32)
+	for i := range priv {
+		priv[i] = byte(i + 1)
+	}
+	hash := sha256.Sum256([]byte("siec test vector"))
+
+	const wantHash = "2681954cb312f4cd0fcca7fda1b251e0c62a9b76f3a80c555cc6731c2d95e1ba"
+	if got := hex.EncodeToString(hash[:]); got != wantHash {
+		t.Fatalf("sha256(\"siec test vector\") = %s, want %s (test vector itself changed?)", got, wantHash)
+	}
+
+	r, s, err := SignRFC6979(priv, hash[:], sha256.New)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantR, _ := new(big.Int).SetString("3632b10526e1a3b7f7945fc97df7b834596092be99f484b777949c73c4eeefb3", 16)
+	wantS, _ := new(big.Int).SetString("31a709cc817a1c5042a0d79bf351a7444e03d23b66e0d8d3e3f4a907c4cf5916", 16)
+	if r.Cmp(wantR) != 0 || s.Cmp(wantS) != 0 {
+		t.Fatalf("SignRFC6979(priv, hash) = (%s, %s), want (%s, %s)", r.Text(16), s.Text(16), wantR.Text(16), wantS.Text(16))
+	}
+
+	curve := SIEC255()
+	x, y := curve.ScalarBaseMultCT(priv)
+	if !Verify(x, y, hash[:], r, s) {
+		t.Fatal("pinned (r, s) does not verify against the pinned public key")
+	}
+}
+
+func TestVerifyRejectsOutOfRangeSignature(t *testing.T) {
+	_, x, y, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := sha256.Sum256([]byte("out of range"))
+	curve := SIEC255()
+
+	if Verify(x, y, hash[:], new(big.Int), big.NewInt(1)) {
+		t.Fatal("Verify accepted r = 0")
+	}
+	if Verify(x, y, hash[:], big.NewInt(1), new(big.Int)) {
+		t.Fatal("Verify accepted s = 0")
+	}
+	if Verify(x, y, hash[:], curve.N, big.NewInt(1)) {
+		t.Fatal("Verify accepted r >= N")
+	}
+}
+
+func TestVerifyASN1RejectsGarbage(t *testing.T) {
+	_, x, y, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := sha256.Sum256([]byte("garbage"))
+	if VerifyASN1(x, y, hash[:], []byte("not asn.1")) {
+		t.Fatal("VerifyASN1 accepted non-ASN.1 garbage")
+	}
+	if VerifyASN1(x, y, hash[:], nil) {
+		t.Fatal("VerifyASN1 accepted an empty signature")
+	}
+}
@@ -0,0 +1,163 @@
+package siec
+
+import "math/big"
+
+// This file implements point arithmetic in Jacobian coordinates, where the
+// affine point is x = X/Z², y = Y/Z³. Operating in Jacobian coordinates
+// avoids a modular inverse on every Add/Double, which otherwise dominates the
+// cost of ScalarMult; only affineFromJacobian needs one, at the very end of
+// the double-and-add loop. The approach mirrors crypto/elliptic and btcec.
+
+// zForAffine returns a Jacobian Z value for the affine point (x,y). It
+// returns 0 for the point at infinity, represented as (0,0).
+func zForAffine(x, y *big.Int) *big.Int {
+	z := new(big.Int)
+	if x.Sign() != 0 || y.Sign() != 0 {
+		z.SetInt64(1)
+	}
+	return z
+}
+
+// affineFromJacobian converts a Jacobian point back to affine coordinates.
+// It returns (0,0), the point at infinity, if z is zero.
+func (curve *SIEC255Params) affineFromJacobian(x, y, z *big.Int) (xOut, yOut *big.Int) {
+	if z.Sign() == 0 {
+		return new(big.Int), new(big.Int)
+	}
+
+	zinv := new(big.Int).ModInverse(z, curve.P)
+	zinvsq := new(big.Int).Mul(zinv, zinv)
+
+	xOut = new(big.Int).Mul(x, zinvsq)
+	xOut.Mod(xOut, curve.P)
+	zinvsq.Mul(zinvsq, zinv)
+	yOut = new(big.Int).Mul(y, zinvsq)
+	yOut.Mod(yOut, curve.P)
+	return xOut, yOut
+}
+
+// addJacobian returns the sum of (x1,y1,z1) and (x2,y2,z2) in Jacobian
+// coordinates. This is the generic point-addition formula, which doesn't
+// depend on the curve's A coefficient, so it works unchanged for SIEC255's
+// a=0. Points that are inverses of each other (same x, opposite y) fall out
+// of the formula as z3=0, i.e. the point at infinity, rather than needing a
+// special case.
+func (curve *SIEC255Params) addJacobian(x1, y1, z1, x2, y2, z2 *big.Int) (x3, y3, z3 *big.Int) {
+	x3, y3, z3 = new(big.Int), new(big.Int), new(big.Int)
+	if z1.Sign() == 0 {
+		x3.Set(x2)
+		y3.Set(y2)
+		z3.Set(z2)
+		return
+	}
+	if z2.Sign() == 0 {
+		x3.Set(x1)
+		y3.Set(y1)
+		z3.Set(z1)
+		return
+	}
+
+	z1z1 := new(big.Int).Mul(z1, z1)
+	z1z1.Mod(z1z1, curve.P)
+	z2z2 := new(big.Int).Mul(z2, z2)
+	z2z2.Mod(z2z2, curve.P)
+
+	u1 := new(big.Int).Mul(x1, z2z2)
+	u1.Mod(u1, curve.P)
+	u2 := new(big.Int).Mul(x2, z1z1)
+	u2.Mod(u2, curve.P)
+	h := new(big.Int).Sub(u2, u1)
+	xEqual := h.Sign() == 0
+	if h.Sign() == -1 {
+		h.Add(h, curve.P)
+	}
+	i := new(big.Int).Lsh(h, 1)
+	i.Mul(i, i)
+	j := new(big.Int).Mul(h, i)
+
+	s1 := new(big.Int).Mul(y1, z2)
+	s1.Mul(s1, z2z2)
+	s1.Mod(s1, curve.P)
+	s2 := new(big.Int).Mul(y2, z1)
+	s2.Mul(s2, z1z1)
+	s2.Mod(s2, curve.P)
+	r := new(big.Int).Sub(s2, s1)
+	if r.Sign() == -1 {
+		r.Add(r, curve.P)
+	}
+	yEqual := r.Sign() == 0
+	if xEqual && yEqual {
+		return curve.doubleJacobian(x1, y1, z1)
+	}
+	r.Lsh(r, 1)
+	v := new(big.Int).Mul(u1, i)
+
+	x3.Set(r)
+	x3.Mul(x3, x3)
+	x3.Sub(x3, j)
+	x3.Sub(x3, v)
+	x3.Sub(x3, v)
+	x3.Mod(x3, curve.P)
+
+	y3.Set(r)
+	v.Sub(v, x3)
+	y3.Mul(y3, v)
+	s1.Mul(s1, j)
+	s1.Lsh(s1, 1)
+	y3.Sub(y3, s1)
+	y3.Mod(y3, curve.P)
+
+	z3.Add(z1, z2)
+	z3.Mul(z3, z3)
+	z3.Sub(z3, z1z1)
+	z3.Sub(z3, z2z2)
+	z3.Mul(z3, h)
+	z3.Mod(z3, curve.P)
+
+	return x3, y3, z3
+}
+
+// doubleJacobian returns 2*(x,y,z) in Jacobian coordinates. Since SIEC255
+// has a=0, the general a-dependent doubling formula's M = 3X²+aZ⁴ term
+// simplifies to M = 3X², eliminating the Z⁴ term entirely.
+func (curve *SIEC255Params) doubleJacobian(x, y, z *big.Int) (x3, y3, z3 *big.Int) {
+	// A = X²
+	a := new(big.Int).Mul(x, x)
+	a.Mod(a, curve.P)
+	// B = Y²
+	b := new(big.Int).Mul(y, y)
+	b.Mod(b, curve.P)
+	// C = B²
+	c := new(big.Int).Mul(b, b)
+	c.Mod(c, curve.P)
+
+	// D = 2*((X+B)²-A-C)
+	d := new(big.Int).Add(x, b)
+	d.Mul(d, d)
+	d.Sub(d, a)
+	d.Sub(d, c)
+	d.Mul(d, two)
+	d.Mod(d, curve.P)
+
+	// E = 3A
+	e := new(big.Int).Mul(three, a)
+	e.Mod(e, curve.P)
+	// F = E²
+	f := new(big.Int).Mul(e, e)
+
+	x3 = new(big.Int).Mul(two, d)
+	x3.Sub(f, x3)
+	x3.Mod(x3, curve.P)
+
+	y3 = new(big.Int).Sub(d, x3)
+	y3.Mul(e, y3)
+	c8 := new(big.Int).Lsh(c, 3)
+	y3.Sub(y3, c8)
+	y3.Mod(y3, curve.P)
+
+	z3 = new(big.Int).Mul(y, z)
+	z3.Mul(z3, two)
+	z3.Mod(z3, curve.P)
+
+	return x3, y3, z3
+}
@@ -1,4 +1,6 @@
-package main
+// Package siec implements the SIEC255 elliptic curve, a short-Weierstrass
+// curve y² = x³ + 19 over a 255-bit prime field.
+package siec
 
 import (
 	"fmt"
@@ -9,7 +11,6 @@ import (
 
 var (
 	initonce sync.Once
-	zero     = big.NewInt(0)
 	two      = big.NewInt(2)
 	three    = big.NewInt(3)
 	nineteen = big.NewInt(19)
@@ -36,82 +37,46 @@ func (curve *SIEC255Params) Params() *SIEC255Params {
 func (curve *SIEC255Params) IsOnCurve(x, y *big.Int) bool {
 	// y² = x³ + 19
 	y2 := new(big.Int).Exp(y, two, curve.P)
-	x3 := new(big.Int).Exp(x, two, curve.P)
-	return y2.Cmp(x3.Add(x3, nineteen)) == 0
+	x3 := new(big.Int).Exp(x, three, curve.P)
+	x3.Add(x3, nineteen)
+	x3.Mod(x3, curve.P)
+	return y2.Cmp(x3) == 0
 }
 
-// Add returns the sum of (x1,y1) and (x2,y2)
+// Add returns the sum of (x1,y1) and (x2,y2). The point at infinity is
+// represented by (0,0); Add returns (0,0) when given two points that are
+// inverses of each other, rather than dropping to the affine formula's
+// undefined λ.
 func (curve *SIEC255Params) Add(x1, y1, x2, y2 *big.Int) (x, y *big.Int) {
-	if x1.BitLen() == 0 && y1.BitLen() == 0 {
-		return x2, y2
-	}
-	if x2.BitLen() == 0 && y2.BitLen() == 0 {
-		return x1, y1
-	}
-	if x1.Cmp(x2) == 0 && y1.Cmp(y2) == 0 {
-		return curve.Double(x1, y1)
-	}
-	// TODO: optimize
-	// λ = (y2 - y1)/(x2 - x1)
-	lambda := new(big.Int).Sub(y2, y1)
-	z := new(big.Int).Sub(x2, x1)
-	z.Mod(z, curve.P)
-	if z.BitLen() == 0 {
-		return z.Set(zero), lambda.Set(zero)
-	}
-	z.ModInverse(z, curve.P)
-	lambda.Mul(lambda, z)
-	lambda.Mod(lambda, curve.P)
-	// x3 = λ² - x1 - x2
-	x3 := new(big.Int).Exp(lambda, two, curve.P)
-	x3.Sub(x3, z.Add(x1, x2))
-	x3.Mod(x3, curve.P)
-	// y3 = λ(x1 - x3) - y1
-	y3 := new(big.Int).Mul(lambda, z.Sub(x1, x3))
-	y3.Mod(y3, curve.P)
-	y3.Sub(y3, y1)
-	y3.Mod(y3, curve.P)
-	return x3, y3
+	z1 := zForAffine(x1, y1)
+	z2 := zForAffine(x2, y2)
+	return curve.affineFromJacobian(curve.addJacobian(x1, y1, z1, x2, y2, z2))
 }
 
 // Double returns 2*(x,y)
 func (curve *SIEC255Params) Double(x1, y1 *big.Int) (x, y *big.Int) {
-	x = new(big.Int)
-	y = new(big.Int)
-	// TODO: optimize
-	// λ = (3x1^2)/(2y1)
-	lambda := new(big.Int).Mul(three, x.Exp(x1, two, curve.P))
-	if y1.BitLen() == 0 {
-		return x.Set(zero), y.Set(zero)
-	}
-	x.Mul(two, y1)
-	x.ModInverse(x, curve.P)
-	lambda.Mul(lambda, x)
-	// x3 = λ² - x1 - x2
-	x.Exp(lambda, two, curve.P)
-	x.Sub(x, y.Add(x1, x1))
-	x.Mod(x, curve.P)
-	// y = λ(x1 - x) - y1
-	y.Mul(lambda, new(big.Int).Sub(x1, x))
-	y.Mod(y, curve.P)
-	y.Sub(y, y1)
-	y.Mod(y, curve.P)
-	return
+	z1 := zForAffine(x1, y1)
+	return curve.affineFromJacobian(curve.doubleJacobian(x1, y1, z1))
 }
 
-// ScalarMult returns k*(Bx,By) where k is a number in big-endian form.
+// ScalarMult returns k*(Bx,By) where k is a number in big-endian form. It
+// accumulates in Jacobian coordinates throughout the double-and-add loop and
+// converts back to affine only once at the end, with a single ModInverse.
+// (0,0) is treated as the point at infinity throughout.
 func (curve *SIEC255Params) ScalarMult(x1, y1 *big.Int, k []byte) (x, y *big.Int) {
-	x, y = new(big.Int), new(big.Int)
+	Bz := zForAffine(x1, y1)
+	x, y, z := new(big.Int), new(big.Int), new(big.Int)
+
 	for _, b := range k {
 		for bitNum := 0; bitNum < 8; bitNum++ {
-			x, y = curve.Double(x, y)
+			x, y, z = curve.doubleJacobian(x, y, z)
 			if b&0x80 == 0x80 { // if top bit set
-				x, y = curve.Add(x1, y1, x, y)
+				x, y, z = curve.addJacobian(x1, y1, Bz, x, y, z)
 			}
 			b <<= 1
 		}
 	}
-	return x, y
+	return curve.affineFromJacobian(x, y, z)
 }
 
 // ScalarBaseMult returns k*G, where G is the base point of the group
@@ -120,28 +85,71 @@ func (curve *SIEC255Params) ScalarBaseMult(k []byte) (x, y *big.Int) {
 	return curve.ScalarMult(curve.Gx, curve.Gy, k)
 }
 
-// LiftX returns a point on the curve (x,y) with the given x-value.
-// If there is more than one, it returns the one whose y-value
-// is smaller in the interval [0,p). If no such point exists,
-// then this function panics.
-func LiftX(X *big.Int) (x, y *big.Int) {
-	params := SIEC255().Params()
-	// y² = x³ + Ax + B
-	x = new(big.Int).Set(X)
-	y = new(big.Int)
-	y.Exp(x, three, params.P)
+// curveSqrtY returns a square root of x³ + Ax + B mod P — a y-coordinate of
+// a point on the curve with the given x — or an error if x doesn't
+// correspond to a point on the curve. The other root is P minus the
+// returned value; callers pick between them however they need to.
+func curveSqrtY(x *big.Int) (*big.Int, error) {
+	params := SIEC255()
+	y := new(big.Int).Exp(x, three, params.P)
 	y.Add(y, new(big.Int).Mul(x, params.A))
 	y.Mod(y, params.P)
 	y.Add(y, params.B)
 	y.Mod(y, params.P)
 	y = y.ModSqrt(y, params.P)
 	if y == nil {
-		panic(fmt.Sprintf("%d is not a point on the curve", X))
+		return nil, fmt.Errorf("siec: %s is not a point on the curve", x)
+	}
+	return y, nil
+}
+
+// LiftX returns a point on the curve (x,y) with the given x-value.
+// If there is more than one, it returns the one whose y-value
+// is smaller in the interval [0,p). If no such point exists,
+// then this function panics.
+//
+// Deprecated: prefer DecompressPoint, which reports an error instead of
+// panicking when X is not on the curve.
+func LiftX(X *big.Int) (x, y *big.Int) {
+	y, err := curveSqrtY(X)
+	if err != nil {
+		panic(err.Error())
 	}
-	if y.Cmp(new(big.Int).Sub(params.P, y)) > 0 {
+	if params := SIEC255(); y.Cmp(new(big.Int).Sub(params.P, y)) > 0 {
 		y.Sub(params.P, y)
 	}
-	return x, y
+	return new(big.Int).Set(X), y
+}
+
+// DecompressPoint returns the point on the curve with the given x-coordinate
+// and the y whose parity (odd/even) matches yOdd — the SEC1 convention
+// carried by the 0x02/0x03 prefix of a compressed point encoding. Unlike
+// LiftX, it reports an error instead of panicking when x does not
+// correspond to a point on the curve, so it's safe to call on untrusted
+// input.
+func DecompressPoint(x *big.Int, yOdd bool) (*big.Int, *big.Int, error) {
+	y, err := curveSqrtY(x)
+	if err != nil {
+		return nil, nil, err
+	}
+	wantOdd := uint(0)
+	if yOdd {
+		wantOdd = 1
+	}
+	if y.Bit(0) != wantOdd {
+		y.Sub(SIEC255().P, y)
+	}
+	return new(big.Int).Set(x), y, nil
+}
+
+// CompressPoint encodes (x,y) in the 33-byte SEC1 compressed form
+// 0x02/0x03 || X, with the prefix byte carrying the parity of y.
+func CompressPoint(x, y *big.Int) []byte {
+	byteLen := (SIEC255().BitSize + 7) / 8
+	ret := make([]byte, 1+byteLen)
+	ret[0] = byte(y.Bit(0)) | 2
+	x.FillBytes(ret[1:])
+	return ret
 }
 
 var siec255 *SIEC255Params
@@ -165,19 +173,18 @@ func SIEC255() *SIEC255Params {
 
 var mask = []byte{0xff, 0x1, 0x3, 0x7, 0xf, 0x1f, 0x3f, 0x7f}
 
-// GenerateKey returns a public/private key pair. The private key is
-// generated using the given reader, which must return random data.
-// This is copied from https://golang.org/src/crypto/elliptic/elliptic.go?s=7368:7453#L266
-func GenerateKey(rand io.Reader) (k []byte, x, y *big.Int, err error) {
-	curve := SIEC255()
-	N := curve.Params().N
+// randFieldElement returns a uniformly random scalar in [0, N), reading
+// randomness from rand, masked down to N's bit length (N.BitLen(), not the
+// field's BitSize — a scalar is reduced mod N, not mod P). It retries until
+// the sampled value is in range. Shared by GenerateKey and the ECDSA signer.
+func randFieldElement(rand io.Reader, N *big.Int) (k []byte, err error) {
 	bitSize := N.BitLen()
 	byteLen := (bitSize + 7) >> 3
 	k = make([]byte, byteLen)
-	for x == nil {
+	for {
 		_, err = io.ReadFull(rand, k)
 		if err != nil {
-			return
+			return nil, err
 		}
 		// We have to mask off any excess bits in the case that the size of the
 		// underlying field is not a whole number of bytes.
@@ -189,11 +196,22 @@ func GenerateKey(rand io.Reader) (k []byte, x, y *big.Int, err error) {
 		if new(big.Int).SetBytes(k).Cmp(N) >= 0 {
 			continue
 		}
-		x, y = curve.ScalarBaseMult(k)
+		return k, nil
 	}
-	return
 }
 
-func main() {
-	fmt.Println("hello")
+// GenerateKey returns a public/private key pair. The private key is
+// generated using the given reader, which must return random data.
+// This is copied from https://golang.org/src/crypto/elliptic/elliptic.go?s=7368:7453#L266
+//
+// k is secret, so the base-point multiplication goes through
+// ScalarBaseMultCT rather than the non-constant-time ScalarBaseMult.
+func GenerateKey(rand io.Reader) (k []byte, x, y *big.Int, err error) {
+	curve := SIEC255()
+	k, err = randFieldElement(rand, curve.Params().N)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	x, y = curve.ScalarBaseMultCT(k)
+	return
 }
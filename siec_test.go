@@ -0,0 +1,71 @@
+package siec
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestBasePointIsOnCurve(t *testing.T) {
+	curve := SIEC255()
+	if !curve.IsOnCurve(curve.Gx, curve.Gy) {
+		t.Fatal("base point G is not reported as on the curve")
+	}
+}
+
+func TestDoubleMatchesAdd(t *testing.T) {
+	curve := SIEC255()
+	dx, dy := curve.Double(curve.Gx, curve.Gy)
+	ax, ay := curve.Add(curve.Gx, curve.Gy, curve.Gx, curve.Gy)
+	if dx.Cmp(ax) != 0 || dy.Cmp(ay) != 0 {
+		t.Fatalf("Double(G) = (%s, %s), Add(G, G) = (%s, %s)", dx, dy, ax, ay)
+	}
+	if !curve.IsOnCurve(dx, dy) {
+		t.Fatal("2G is not on the curve")
+	}
+}
+
+func TestAddInversePointsIsInfinity(t *testing.T) {
+	curve := SIEC255()
+	negGy := new(big.Int).Sub(curve.P, curve.Gy)
+	x, y := curve.Add(curve.Gx, curve.Gy, curve.Gx, negGy)
+	if x.Sign() != 0 || y.Sign() != 0 {
+		t.Fatalf("G + (-G) = (%s, %s), want (0, 0)", x, y)
+	}
+}
+
+func TestAddIdentityIsNoOp(t *testing.T) {
+	curve := SIEC255()
+	zero := new(big.Int)
+	x, y := curve.Add(zero, zero, curve.Gx, curve.Gy)
+	if x.Cmp(curve.Gx) != 0 || y.Cmp(curve.Gy) != 0 {
+		t.Fatalf("O + G = (%s, %s), want G", x, y)
+	}
+	x, y = curve.Add(curve.Gx, curve.Gy, zero, zero)
+	if x.Cmp(curve.Gx) != 0 || y.Cmp(curve.Gy) != 0 {
+		t.Fatalf("G + O = (%s, %s), want G", x, y)
+	}
+}
+
+func TestScalarMultOrderIsInfinity(t *testing.T) {
+	curve := SIEC255()
+	x, y := curve.ScalarMult(curve.Gx, curve.Gy, curve.N.Bytes())
+	if x.Sign() != 0 || y.Sign() != 0 {
+		t.Fatalf("N*G = (%s, %s), want the point at infinity", x, y)
+	}
+}
+
+func TestScalarMultAgreesWithRepeatedAdd(t *testing.T) {
+	curve := SIEC255()
+	x, y := curve.Gx, curve.Gy
+	for n := int64(1); n <= 8; n++ {
+		got := new(big.Int).SetInt64(n)
+		gx, gy := curve.ScalarMult(curve.Gx, curve.Gy, got.Bytes())
+		if gx.Cmp(x) != 0 || gy.Cmp(y) != 0 {
+			t.Fatalf("ScalarMult(G, %d) = (%s, %s), want (%s, %s)", n, gx, gy, x, y)
+		}
+		if !curve.IsOnCurve(gx, gy) {
+			t.Fatalf("%d*G is not on the curve", n)
+		}
+		x, y = curve.Add(x, y, curve.Gx, curve.Gy)
+	}
+}